@@ -0,0 +1,78 @@
+package plain
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// cacheEntry is the value stored in a cache's backing list.
+type cacheEntry struct {
+	key     string
+	doc     Document
+	expires time.Time
+}
+
+// cache is a small in-memory LRU cache of Documents, used by Server to avoid
+// re-fetching and re-extracting the same page on every request. Entries older
+// than ttl are treated as a miss and re-extracted.
+type cache struct {
+	mu       sync.Mutex
+	size     int
+	ttl      time.Duration
+	list     *list.List
+	elements map[string]*list.Element
+}
+
+func newCache(size int, ttl time.Duration) *cache {
+	return &cache{
+		size:     size,
+		ttl:      ttl,
+		list:     list.New(),
+		elements: make(map[string]*list.Element),
+	}
+}
+
+func (c *cache) get(key string) (Document, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.elements[key]
+	if !ok {
+		return Document{}, false
+	}
+
+	entry := el.Value.(*cacheEntry)
+	if time.Now().After(entry.expires) {
+		c.list.Remove(el)
+		delete(c.elements, key)
+		return Document{}, false
+	}
+
+	c.list.MoveToFront(el)
+	return entry.doc, true
+}
+
+func (c *cache) set(key string, doc Document) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.elements[key]; ok {
+		entry := el.Value.(*cacheEntry)
+		entry.doc = doc
+		entry.expires = time.Now().Add(c.ttl)
+		c.list.MoveToFront(el)
+		return
+	}
+
+	entry := &cacheEntry{key: key, doc: doc, expires: time.Now().Add(c.ttl)}
+	c.elements[key] = c.list.PushFront(entry)
+
+	if c.list.Len() > c.size {
+		oldest := c.list.Back()
+		if oldest != nil {
+			c.list.Remove(oldest)
+			delete(c.elements, oldest.Value.(*cacheEntry).key)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package plain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCacheGetSetRoundTrip(t *testing.T) {
+	c := newCache(10, time.Minute)
+
+	if _, ok := c.get("missing"); ok {
+		t.Fatal("get on empty cache should miss")
+	}
+
+	want := Document{Metadata: Metadata{Title: "A"}}
+	c.set("a", want)
+
+	got, ok := c.get("a")
+	if !ok {
+		t.Fatal("expected a hit after set")
+	}
+	if got.Metadata.Title != want.Metadata.Title {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestCacheSetUpdatesExistingEntry(t *testing.T) {
+	c := newCache(10, time.Minute)
+
+	c.set("a", Document{Metadata: Metadata{Title: "old"}})
+	c.set("a", Document{Metadata: Metadata{Title: "new"}})
+
+	got, ok := c.get("a")
+	if !ok {
+		t.Fatal("expected a hit")
+	}
+	if got.Metadata.Title != "new" {
+		t.Errorf("title = %q, want %q", got.Metadata.Title, "new")
+	}
+	if c.list.Len() != 1 {
+		t.Errorf("list length = %d, want 1 (update shouldn't add a new entry)", c.list.Len())
+	}
+}
+
+func TestCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	c := newCache(2, time.Minute)
+
+	c.set("a", Document{Metadata: Metadata{Title: "a"}})
+	c.set("b", Document{Metadata: Metadata{Title: "b"}})
+
+	// Touch "a" so "b" becomes the least recently used entry.
+	if _, ok := c.get("a"); !ok {
+		t.Fatal("expected a hit")
+	}
+
+	c.set("c", Document{Metadata: Metadata{Title: "c"}})
+
+	if _, ok := c.get("b"); ok {
+		t.Error("expected b to be evicted as least recently used")
+	}
+	if _, ok := c.get("a"); !ok {
+		t.Error("expected a to survive eviction")
+	}
+	if _, ok := c.get("c"); !ok {
+		t.Error("expected c to be present")
+	}
+}
+
+func TestCacheExpiresEntriesPastTTL(t *testing.T) {
+	c := newCache(10, time.Millisecond)
+
+	c.set("a", Document{Metadata: Metadata{Title: "a"}})
+	time.Sleep(5 * time.Millisecond)
+
+	if _, ok := c.get("a"); ok {
+		t.Error("expected entry to be expired")
+	}
+	if _, ok := c.elements["a"]; ok {
+		t.Error("expired entry should be removed from the cache, not just hidden")
+	}
+}
@@ -0,0 +1,282 @@
+package plain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// DefaultUserAgent is the User-Agent header sent with every request unless
+// ClientOptions.UserAgent overrides it.
+const DefaultUserAgent = "plain/1.0 (+https://github.com/qsymmachus/plain)"
+
+// DefaultTimeout is the default per-request timeout.
+const DefaultTimeout = 30 * time.Second
+
+// DefaultMaxRetries is how many times a request is retried on a 5xx response
+// or network error before giving up.
+const DefaultMaxRetries = 2
+
+// DefaultRetryBackoff is the base delay before the first retry; it doubles on
+// each subsequent attempt.
+const DefaultRetryBackoff = 500 * time.Millisecond
+
+// maxSnippetLines and maxSnippetBytes bound the response body excerpt a
+// StatusError includes for short, textual error responses.
+const maxSnippetLines = 8
+const maxSnippetBytes = 650
+
+// ClientOptions configures the HTTP client an Extractor uses to fetch pages.
+type ClientOptions struct {
+	// Timeout bounds a single request, including retries. Defaults to
+	// DefaultTimeout.
+	Timeout time.Duration
+
+	// UserAgent is sent as the User-Agent header. Defaults to
+	// DefaultUserAgent.
+	UserAgent string
+
+	// MaxRetries is how many times to retry a 5xx response or network error.
+	// Defaults to DefaultMaxRetries.
+	MaxRetries int
+
+	// RetryBackoff is the base delay between retries. Defaults to
+	// DefaultRetryBackoff.
+	RetryBackoff time.Duration
+}
+
+func (c ClientOptions) withDefaults() ClientOptions {
+	if c.Timeout == 0 {
+		c.Timeout = DefaultTimeout
+	}
+	if c.UserAgent == "" {
+		c.UserAgent = DefaultUserAgent
+	}
+	if c.MaxRetries == 0 {
+		c.MaxRetries = DefaultMaxRetries
+	}
+	if c.RetryBackoff == 0 {
+		c.RetryBackoff = DefaultRetryBackoff
+	}
+
+	return c
+}
+
+// StatusError is returned when a request completes but its status code isn't
+// 200. For short, text/plain responses it carries a truncated snippet of the
+// body to help diagnose the failure.
+type StatusError struct {
+	URL     string
+	Status  string
+	Snippet string
+}
+
+func (e *StatusError) Error() string {
+	if e.Snippet == "" {
+		return fmt.Sprintf("%s: unexpected status code: %s", e.URL, e.Status)
+	}
+
+	return fmt.Sprintf("%s: unexpected status code: %s\n%s", e.URL, e.Status, e.Snippet)
+}
+
+// loadPage sends an HTTP GET request to rawURL and returns the response,
+// retrying 5xx responses and network errors with exponential backoff.
+// opts.Timeout bounds the whole call, retries included, not each individual
+// attempt.
+func loadPage(ctx context.Context, rawURL string, opts ClientOptions) (*http.Response, error) {
+	opts = opts.withDefaults()
+
+	ctx, cancel := context.WithTimeout(ctx, opts.Timeout)
+
+	client := &http.Client{CheckRedirect: disallowHTTPSDowngrade}
+
+	var lastErr error
+	for attempt := 0; attempt <= opts.MaxRetries; attempt++ {
+		if attempt > 0 {
+			backoff := opts.RetryBackoff * time.Duration(int64(1)<<uint(attempt-1))
+			select {
+			case <-time.After(backoff):
+			case <-ctx.Done():
+				cancel()
+				return nil, ctx.Err()
+			}
+		}
+
+		response, err := doRequest(ctx, client, rawURL, opts.UserAgent)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+
+		if response.StatusCode >= 500 {
+			lastErr = &StatusError{URL: rawURL, Status: response.Status, Snippet: bodySnippet(response)}
+			response.Body.Close()
+			continue
+		}
+
+		if response.StatusCode != 200 {
+			err := &StatusError{URL: rawURL, Status: response.Status, Snippet: bodySnippet(response)}
+			response.Body.Close()
+			cancel()
+			return nil, err
+		}
+
+		// The caller reads the body after we return, so we can't cancel yet;
+		// tie cancellation to the body's Close instead, keeping the overall
+		// timeout in force for as long as the body is being read.
+		response.Body = &cancelOnClose{ReadCloser: response.Body, cancel: cancel}
+		return response, nil
+	}
+
+	cancel()
+	return nil, lastErr
+}
+
+// cancelOnClose wraps a response body so that closing it also cancels the
+// context that bounded the request (and any retries) that produced it.
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (c *cancelOnClose) Close() error {
+	err := c.ReadCloser.Close()
+	c.cancel()
+	return err
+}
+
+// disallowHTTPSDowngrade is an http.Client.CheckRedirect that refuses to
+// follow a redirect from https back down to plain http.
+func disallowHTTPSDowngrade(req *http.Request, via []*http.Request) error {
+	if len(via) > 0 && via[0].URL.Scheme == "https" && req.URL.Scheme == "http" {
+		return errors.New("refusing to follow HTTPS -> HTTP redirect")
+	}
+
+	return nil
+}
+
+// doRequest issues a single GET request, attaching the configured User-Agent
+// and, if present, .netrc credentials for the request's host.
+func doRequest(ctx context.Context, client *http.Client, rawURL, userAgent string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", userAgent)
+
+	if user, pass, ok := netrcCredentials(req.URL.Host); ok {
+		req.SetBasicAuth(user, pass)
+	}
+
+	return client.Do(req)
+}
+
+// bodySnippet returns an indented excerpt of a short, text/plain response
+// body, bounded at maxSnippetLines lines and maxSnippetBytes bytes. It
+// returns "" for anything else, so we never buffer or echo arbitrary
+// (possibly huge, possibly binary) response bodies.
+func bodySnippet(response *http.Response) string {
+	mediaType, _, err := mime.ParseMediaType(response.Header.Get("Content-Type"))
+	if err != nil || mediaType != "text/plain" {
+		return ""
+	}
+
+	data, err := ioutil.ReadAll(io.LimitReader(response.Body, maxSnippetBytes+1))
+	if err != nil || len(data) == 0 {
+		return ""
+	}
+
+	truncated := len(data) > maxSnippetBytes
+	if truncated {
+		data = data[:maxSnippetBytes]
+	}
+
+	lines := strings.Split(strings.TrimRight(string(data), "\n"), "\n")
+	if len(lines) > maxSnippetLines {
+		lines = lines[:maxSnippetLines]
+		truncated = true
+	}
+
+	var b strings.Builder
+	for _, line := range lines {
+		b.WriteString("    ")
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+	if truncated {
+		b.WriteString("    ...\n")
+	}
+
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// netrcCredentials looks up basic-auth credentials for host in the user's
+// .netrc file, the same lookup `go` itself does for authenticated module
+// downloads.
+func netrcCredentials(host string) (user, pass string, ok bool) {
+	path := netrcPath()
+	if path == "" {
+		return "", "", false
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", "", false
+	}
+
+	fields := strings.Fields(string(data))
+	var machine, login, password string
+	matched := false
+
+	for i := 0; i < len(fields); i++ {
+		switch fields[i] {
+		case "machine":
+			if i+1 < len(fields) {
+				machine = fields[i+1]
+				matched = machine == host
+				login, password = "", ""
+			}
+		case "login":
+			if matched && i+1 < len(fields) {
+				login = fields[i+1]
+			}
+		case "password":
+			if matched && i+1 < len(fields) {
+				password = fields[i+1]
+			}
+		}
+
+		if matched && login != "" && password != "" {
+			return login, password, true
+		}
+	}
+
+	return "", "", false
+}
+
+func netrcPath() string {
+	if p := os.Getenv("NETRC"); p != "" {
+		return p
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+
+	name := ".netrc"
+	if runtime.GOOS == "windows" {
+		name = "_netrc"
+	}
+
+	return filepath.Join(home, name)
+}
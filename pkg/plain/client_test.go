@@ -0,0 +1,145 @@
+package plain
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestLoadPageRetriesOnServerErrorThenSucceeds(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests < 3 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	opts := ClientOptions{Timeout: time.Second, MaxRetries: 2, RetryBackoff: time.Millisecond}
+	response, err := loadPage(context.Background(), server.URL, opts)
+	if err != nil {
+		t.Fatalf("loadPage: %v", err)
+	}
+	defer response.Body.Close()
+
+	if requests != 3 {
+		t.Errorf("got %d requests, want 3 (2 failures + 1 success)", requests)
+	}
+}
+
+func TestLoadPageGivesUpAfterMaxRetries(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	opts := ClientOptions{Timeout: time.Second, MaxRetries: 2, RetryBackoff: time.Millisecond}
+	_, err := loadPage(context.Background(), server.URL, opts)
+	if err == nil {
+		t.Fatal("expected an error after exhausting retries")
+	}
+
+	if want := 3; requests != want { // one initial attempt plus MaxRetries retries
+		t.Errorf("got %d requests, want %d", requests, want)
+	}
+}
+
+func TestLoadPageDoesNotRetryClientErrors(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	opts := ClientOptions{Timeout: time.Second, MaxRetries: 2, RetryBackoff: time.Millisecond}
+	_, err := loadPage(context.Background(), server.URL, opts)
+	if err == nil {
+		t.Fatal("expected a 404 to surface as an error")
+	}
+	if requests != 1 {
+		t.Errorf("got %d requests, want 1 (4xx responses aren't retried)", requests)
+	}
+}
+
+func TestLoadPageTimeoutBoundsTheWholeRetryLoop(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	// A backoff that, summed across retries, would outlast Timeout if the
+	// timeout were applied per-attempt instead of to the whole call.
+	opts := ClientOptions{Timeout: 100 * time.Millisecond, MaxRetries: 5, RetryBackoff: 50 * time.Millisecond}
+
+	start := time.Now()
+	_, err := loadPage(context.Background(), server.URL, opts)
+	elapsed := time.Since(start)
+
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if elapsed > 500*time.Millisecond {
+		t.Errorf("loadPage took %s, want it bounded by the configured timeout", elapsed)
+	}
+}
+
+func TestNetrcCredentialsMatchesHost(t *testing.T) {
+	dir := t.TempDir()
+	netrc := filepath.Join(dir, ".netrc")
+	if err := ioutil.WriteFile(netrc, []byte("machine example.com login alice password secret\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("NETRC", netrc)
+
+	user, pass, ok := netrcCredentials("example.com")
+	if !ok || user != "alice" || pass != "secret" {
+		t.Errorf("netrcCredentials = %q, %q, %v; want alice, secret, true", user, pass, ok)
+	}
+
+	if _, _, ok := netrcCredentials("other.test"); ok {
+		t.Error("expected no match for an unlisted host")
+	}
+}
+
+func TestNetrcCredentialsMissingFile(t *testing.T) {
+	t.Setenv("NETRC", filepath.Join(t.TempDir(), "does-not-exist"))
+
+	if _, _, ok := netrcCredentials("example.com"); ok {
+		t.Error("expected no credentials when the netrc file doesn't exist")
+	}
+}
+
+func TestDisallowHTTPSDowngrade(t *testing.T) {
+	httpsReq := &http.Request{URL: mustParseURL(t, "https://example.com")}
+	httpReq := &http.Request{URL: mustParseURL(t, "http://example.com")}
+
+	if err := disallowHTTPSDowngrade(httpReq, []*http.Request{httpsReq}); err == nil {
+		t.Error("expected an https->http redirect to be refused")
+	}
+
+	if err := disallowHTTPSDowngrade(httpReq, []*http.Request{httpReq}); err != nil {
+		t.Errorf("http->http redirect should be allowed: %v", err)
+	}
+}
+
+func mustParseURL(t *testing.T, raw string) *url.URL {
+	t.Helper()
+	u, err := url.Parse(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return u
+}
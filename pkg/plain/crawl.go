@@ -0,0 +1,324 @@
+package plain
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/PuerkitoBio/goquery"
+	"golang.org/x/time/rate"
+)
+
+// DefaultCrawlConcurrency is how many pages Crawl fetches at once by default.
+const DefaultCrawlConcurrency = 4
+
+// DefaultCrawlRateLimit is the default cap on requests per second to any
+// single host.
+const DefaultCrawlRateLimit rate.Limit = 1
+
+// CrawlOptions configures Crawl.
+type CrawlOptions struct {
+	// Options are the extraction options applied to every page.
+	Options Options
+
+	// Depth is how many hops from the start URL to follow same-host links.
+	// A depth of 0 only crawls the start URL.
+	Depth int
+
+	// Concurrency is how many pages are fetched at once. Defaults to
+	// DefaultCrawlConcurrency.
+	Concurrency int
+
+	// RateLimit caps requests per second to any single host. Defaults to
+	// DefaultCrawlRateLimit.
+	RateLimit rate.Limit
+
+	// OutDir is the directory plaintext files and manifest.json are written
+	// to. It's created if it doesn't already exist.
+	OutDir string
+}
+
+// crawlJob is a single URL queued for fetching, at a given depth.
+type crawlJob struct {
+	url   string
+	depth int
+}
+
+// jobQueue is an unbounded, concurrency-safe FIFO queue of crawlJobs. Workers
+// are also the ones discovering new jobs (links found on a page), so a fixed-
+// size buffered channel can deadlock: every worker blocked trying to send a
+// newly discovered link with none left to receive. jobQueue never blocks a
+// push, so that can't happen. pop blocks until a job is available, and
+// reports false once every pushed job has been popped and marked done with
+// nothing left in flight.
+type jobQueue struct {
+	mu      sync.Mutex
+	cond    *sync.Cond
+	jobs    []crawlJob
+	pending int
+	closed  bool
+}
+
+func newJobQueue() *jobQueue {
+	q := &jobQueue{}
+	q.cond = sync.NewCond(&q.mu)
+	return q
+}
+
+// push adds job to the queue and counts it as pending.
+func (q *jobQueue) push(job crawlJob) {
+	q.mu.Lock()
+	q.jobs = append(q.jobs, job)
+	q.pending++
+	q.mu.Unlock()
+
+	q.cond.Signal()
+}
+
+// pop blocks until a job is available or the queue is closed, in which case
+// ok is false and there is no more work coming.
+func (q *jobQueue) pop() (job crawlJob, ok bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for len(q.jobs) == 0 && !q.closed {
+		q.cond.Wait()
+	}
+
+	if len(q.jobs) == 0 {
+		return crawlJob{}, false
+	}
+
+	job, q.jobs = q.jobs[0], q.jobs[1:]
+	return job, true
+}
+
+// done marks one pending job as finished. Once nothing is queued or in
+// flight, the queue closes and wakes any workers blocked in pop.
+func (q *jobQueue) done() {
+	q.mu.Lock()
+	q.pending--
+	if q.pending == 0 {
+		q.closed = true
+		q.cond.Broadcast()
+	}
+	q.mu.Unlock()
+}
+
+// Crawl walks same-host links starting at startURL up to opts.Depth hops,
+// writing one plaintext file per page into opts.OutDir along with a
+// manifest.json mapping each crawled URL to its file. It returns that
+// manifest.
+func Crawl(ctx context.Context, startURL string, opts CrawlOptions) (map[string]string, error) {
+	concurrency := opts.Concurrency
+	if concurrency == 0 {
+		concurrency = DefaultCrawlConcurrency
+	}
+
+	rateLimit := opts.RateLimit
+	if rateLimit == 0 {
+		rateLimit = DefaultCrawlRateLimit
+	}
+
+	start, err := url.Parse(startURL)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(opts.OutDir, 0755); err != nil {
+		return nil, err
+	}
+
+	c := &crawler{
+		extractor: NewExtractor(opts.Options),
+		host:      start.Host,
+		outDir:    opts.OutDir,
+		depth:     opts.Depth,
+		rateLimit: rateLimit,
+		visited:   map[string]bool{},
+		manifest:  map[string]string{},
+		limiters:  map[string]*rate.Limiter{},
+		robots:    map[string]*robotsRules{},
+	}
+
+	queue := newJobQueue()
+	var workers sync.WaitGroup
+
+	for i := 0; i < concurrency; i++ {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			for {
+				job, ok := queue.pop()
+				if !ok {
+					return
+				}
+				c.crawl(ctx, job, queue)
+				queue.done()
+			}
+		}()
+	}
+
+	c.enqueue(queue, crawlJob{url: start.String(), depth: 0})
+	workers.Wait()
+
+	data, err := json.MarshalIndent(c.manifest, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	manifestPath := filepath.Join(opts.OutDir, "manifest.json")
+	if err := ioutil.WriteFile(manifestPath, data, 0644); err != nil {
+		return nil, err
+	}
+
+	return c.manifest, nil
+}
+
+// crawler holds the state shared by a single Crawl call's worker pool: the
+// visited set, per-host rate limiters and robots.txt rules, and the growing
+// manifest of crawled pages.
+type crawler struct {
+	extractor *Extractor
+	host      string
+	outDir    string
+	depth     int
+	rateLimit rate.Limit
+
+	mu       sync.Mutex
+	visited  map[string]bool
+	manifest map[string]string
+	limiters map[string]*rate.Limiter
+	robots   map[string]*robotsRules
+}
+
+// crawl fetches and extracts a single job, writes its plaintext output, and
+// enqueues same-host links found on the page if depth allows.
+func (c *crawler) crawl(ctx context.Context, job crawlJob, queue *jobQueue) {
+	pageURL, err := url.Parse(job.url)
+	if err != nil {
+		return
+	}
+
+	if err := c.limiterFor(pageURL.Host).Wait(ctx); err != nil {
+		return
+	}
+
+	if !c.robotsFor(ctx, pageURL.Scheme, pageURL.Host).allows(pageURL.Path) {
+		return
+	}
+
+	response, err := loadPage(ctx, job.url, c.extractor.Options.Client)
+	if err != nil {
+		return
+	}
+	defer response.Body.Close()
+
+	doc, err := goquery.NewDocumentFromResponse(response)
+	if err != nil {
+		return
+	}
+	doc.Url = pageURL
+
+	extracted, err := c.extractor.extractFromDocument(doc)
+	if err != nil {
+		return
+	}
+
+	filename := slugify(job.url) + ".txt"
+	if err := ioutil.WriteFile(filepath.Join(c.outDir, filename), []byte(extracted.Text()), 0644); err != nil {
+		return
+	}
+
+	c.mu.Lock()
+	c.manifest[job.url] = filename
+	c.mu.Unlock()
+
+	if job.depth >= c.depth {
+		return
+	}
+
+	for _, link := range discoverLinks(doc, pageURL, c.host) {
+		c.enqueue(queue, crawlJob{url: link, depth: job.depth + 1})
+	}
+}
+
+// enqueue pushes job onto queue if its URL hasn't been visited yet.
+func (c *crawler) enqueue(queue *jobQueue, job crawlJob) {
+	c.mu.Lock()
+	if c.visited[job.url] {
+		c.mu.Unlock()
+		return
+	}
+	c.visited[job.url] = true
+	c.mu.Unlock()
+
+	queue.push(job)
+}
+
+func (c *crawler) limiterFor(host string) *rate.Limiter {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	limiter, ok := c.limiters[host]
+	if !ok {
+		limiter = rate.NewLimiter(c.rateLimit, 1)
+		c.limiters[host] = limiter
+	}
+
+	return limiter
+}
+
+func (c *crawler) robotsFor(ctx context.Context, scheme, host string) *robotsRules {
+	c.mu.Lock()
+	rules, ok := c.robots[host]
+	c.mu.Unlock()
+	if ok {
+		return rules
+	}
+
+	rules = fetchRobots(ctx, scheme, host, c.extractor.Options.Client)
+
+	c.mu.Lock()
+	c.robots[host] = rules
+	c.mu.Unlock()
+
+	return rules
+}
+
+// discoverLinks returns the same-host, fragment-stripped links found in doc.
+func discoverLinks(doc *goquery.Document, base *url.URL, host string) []string {
+	var links []string
+	seen := map[string]bool{}
+
+	doc.Find("a[href]").Each(func(i int, s *goquery.Selection) {
+		href, _ := s.Attr("href")
+
+		resolved, err := base.Parse(href)
+		if err != nil || resolved.Host != host {
+			return
+		}
+		resolved.Fragment = ""
+
+		link := resolved.String()
+		if !seen[link] {
+			seen[link] = true
+			links = append(links, link)
+		}
+	})
+
+	return links
+}
+
+var slugPattern = regexp.MustCompile(`[^a-zA-Z0-9]+`)
+
+// slugify turns a URL into a filesystem-safe name.
+func slugify(rawURL string) string {
+	return strings.Trim(slugPattern.ReplaceAllString(rawURL, "-"), "-")
+}
@@ -0,0 +1,111 @@
+package plain
+
+import (
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+func TestJobQueueFanOutDoesNotDeadlock(t *testing.T) {
+	// Regression test: a worker pool much smaller than the number of links a
+	// single page discovers must not be able to deadlock the queue the way a
+	// fixed-size buffered channel shared between producers and consumers can.
+	const workers = 2
+	const roots = 5
+	const childrenPerRoot = 300
+
+	queue := newJobQueue()
+	var mu sync.Mutex
+	processed := 0
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				job, ok := queue.pop()
+				if !ok {
+					return
+				}
+
+				mu.Lock()
+				processed++
+				mu.Unlock()
+
+				if job.depth == 0 {
+					for j := 0; j < childrenPerRoot; j++ {
+						queue.push(crawlJob{depth: job.depth + 1})
+					}
+				}
+
+				queue.done()
+			}
+		}()
+	}
+
+	for i := 0; i < roots; i++ {
+		queue.push(crawlJob{depth: 0})
+	}
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("queue deadlocked: fan-out exceeded what a fixed-size buffer could hold")
+	}
+
+	if want := roots + roots*childrenPerRoot; processed != want {
+		t.Errorf("processed %d jobs, want %d", processed, want)
+	}
+}
+
+func TestDiscoverLinksIsSameHostAndDeduped(t *testing.T) {
+	html := `<html><body>
+		<a href="/a">a</a>
+		<a href="/a">a again</a>
+		<a href="https://example.com/b">b</a>
+		<a href="https://other.test/c">off-host</a>
+		<a href="/d#section">d</a>
+	</body></html>`
+
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	base, _ := url.Parse("https://example.com/start")
+	links := discoverLinks(doc, base, "example.com")
+
+	want := []string{"https://example.com/a", "https://example.com/b", "https://example.com/d"}
+	if len(links) != len(want) {
+		t.Fatalf("got %v, want %v", links, want)
+	}
+	for i, link := range links {
+		if link != want[i] {
+			t.Errorf("link %d: got %q, want %q", i, link, want[i])
+		}
+	}
+}
+
+func TestSlugify(t *testing.T) {
+	cases := map[string]string{
+		"https://example.com/a/b?c=1": "https-example-com-a-b-c-1",
+		"http://example.com/":         "http-example-com",
+	}
+
+	for in, want := range cases {
+		if got := slugify(in); got != want {
+			t.Errorf("slugify(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package plain
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// BlockType identifies the kind of content a Block holds.
+type BlockType int
+
+const (
+	BlockParagraph BlockType = iota
+	BlockHeading
+	BlockList
+	BlockBlockquote
+	BlockCode
+)
+
+// blockTypeNames is the stable wire representation of each BlockType, used by
+// MarshalJSON/UnmarshalJSON so the JSON format doesn't depend on iota order.
+var blockTypeNames = map[BlockType]string{
+	BlockParagraph:  "paragraph",
+	BlockHeading:    "heading",
+	BlockList:       "list",
+	BlockBlockquote: "blockquote",
+	BlockCode:       "code",
+}
+
+// String returns the stable name for t, e.g. "paragraph" or "blockquote".
+func (t BlockType) String() string {
+	if name, ok := blockTypeNames[t]; ok {
+		return name
+	}
+
+	return "unknown"
+}
+
+// MarshalJSON encodes t as its stable string name rather than the underlying
+// int, so the JSON format doesn't change if BlockType's iota is reordered.
+func (t BlockType) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.String())
+}
+
+// UnmarshalJSON decodes a BlockType from its stable string name.
+func (t *BlockType) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err != nil {
+		return err
+	}
+
+	for blockType, candidate := range blockTypeNames {
+		if candidate == name {
+			*t = blockType
+			return nil
+		}
+	}
+
+	return fmt.Errorf("unknown block type %q", name)
+}
+
+// Block is a single structured piece of content extracted from a page, e.g. a
+// paragraph, heading, or list. Not every field is meaningful for every Type:
+// Level only applies to BlockHeading, and Items/Ordered only apply to BlockList.
+type Block struct {
+	Type    BlockType
+	Level   int // heading level, 1-6; zero for non-headings
+	Text    string
+	Items   []string // list item text, for BlockList
+	Ordered bool     // true if a BlockList is an ordered (<ol>) list
+}
+
+// Metadata holds page-level information that isn't part of the body content.
+type Metadata struct {
+	Title     string
+	Author    string
+	Canonical string
+	Language  string
+}
+
+// Document is the structured result of extracting a page's content. It can be
+// rendered to plain text, Markdown, or JSON by consumers of this package.
+type Document struct {
+	Metadata Metadata
+	Blocks   []Block
+}
+
+// Text renders the Document as the plaintext representation `plain` has always
+// produced: paragraphs and headings joined by blank lines, with headings
+// upper-cased.
+func (d Document) Text() string {
+	var parts []string
+
+	for _, b := range d.Blocks {
+		switch b.Type {
+		case BlockHeading:
+			parts = append(parts, strings.ToUpper(b.Text))
+		case BlockParagraph, BlockBlockquote, BlockCode:
+			parts = append(parts, b.Text)
+		case BlockList:
+			parts = append(parts, strings.Join(b.Items, "\n"))
+		}
+	}
+
+	return strings.Join(parts, "\n\n")
+}
+
+// Markdown renders the Document as CommonMark, preserving heading levels and
+// list items.
+func (d Document) Markdown() string {
+	var parts []string
+
+	for _, b := range d.Blocks {
+		switch b.Type {
+		case BlockHeading:
+			parts = append(parts, strings.Repeat("#", b.Level)+" "+b.Text)
+		case BlockParagraph:
+			parts = append(parts, b.Text)
+		case BlockCode:
+			parts = append(parts, "```\n"+b.Text+"\n```")
+		case BlockBlockquote:
+			parts = append(parts, "> "+b.Text)
+		case BlockList:
+			items := make([]string, len(b.Items))
+			for i, item := range b.Items {
+				if b.Ordered {
+					items[i] = fmt.Sprintf("%d. %s", i+1, item)
+				} else {
+					items[i] = "- " + item
+				}
+			}
+			parts = append(parts, strings.Join(items, "\n"))
+		}
+	}
+
+	return strings.Join(parts, "\n\n")
+}
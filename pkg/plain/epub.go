@@ -0,0 +1,91 @@
+package plain
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/bmaupin/go-epub"
+)
+
+// EPUBRenderer packages a Document as a single-file EPUB ebook, useful for
+// turning a page (or, combined with Crawl, a whole site) into something
+// readable offline.
+var EPUBRenderer Renderer = epubRenderer{}
+
+type epubRenderer struct{}
+
+func (epubRenderer) Render(doc Document, w io.Writer) error {
+	title := doc.Metadata.Title
+	if title == "" {
+		title = "Untitled"
+	}
+
+	book := epub.NewEpub(title)
+	if doc.Metadata.Author != "" {
+		book.SetAuthor(doc.Metadata.Author)
+	}
+	if doc.Metadata.Language != "" {
+		book.SetLang(doc.Metadata.Language)
+	}
+
+	if _, err := book.AddSection(epubBody(doc), title, "", ""); err != nil {
+		return err
+	}
+
+	// go-epub only writes to a path, not an io.Writer, so we write to a
+	// scratch file and copy it through.
+	tmp, err := ioutil.TempFile("", "plain-*.epub")
+	if err != nil {
+		return err
+	}
+	tmp.Close()
+	defer os.Remove(tmp.Name())
+
+	if err := book.Write(tmp.Name()); err != nil {
+		return err
+	}
+
+	f, err := os.Open(tmp.Name())
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = io.Copy(w, f)
+	return err
+}
+
+// epubBody renders a Document's blocks as the XHTML body go-epub expects for
+// a section.
+func epubBody(doc Document) string {
+	var body strings.Builder
+
+	for _, b := range doc.Blocks {
+		switch b.Type {
+		case BlockHeading:
+			fmt.Fprintf(&body, "<h%d>%s</h%d>\n", b.Level, html.EscapeString(b.Text), b.Level)
+		case BlockParagraph:
+			fmt.Fprintf(&body, "<p>%s</p>\n", html.EscapeString(b.Text))
+		case BlockCode:
+			fmt.Fprintf(&body, "<pre><code>%s</code></pre>\n", html.EscapeString(b.Text))
+		case BlockBlockquote:
+			fmt.Fprintf(&body, "<blockquote>%s</blockquote>\n", html.EscapeString(b.Text))
+		case BlockList:
+			tag := "ul"
+			if b.Ordered {
+				tag = "ol"
+			}
+			fmt.Fprintf(&body, "<%s>\n", tag)
+			for _, item := range b.Items {
+				fmt.Fprintf(&body, "<li>%s</li>\n", html.EscapeString(item))
+			}
+			fmt.Fprintf(&body, "</%s>\n", tag)
+		}
+	}
+
+	return body.String()
+}
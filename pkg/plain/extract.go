@@ -0,0 +1,80 @@
+package plain
+
+import (
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// blockFrom converts a selected HTML tag into a Block. Headings keep their
+// original case (Document.Text upper-cases them when rendering), and newlines
+// inside paragraphs are replaced with spaces. ok is false if the tag isn't one
+// we know how to turn into a Block.
+func blockFrom(s *goquery.Selection) (Block, bool) {
+	if s == nil || len(s.Nodes) == 0 {
+		return Block{}, false
+	}
+
+	switch s.Nodes[0].Data {
+	case "p":
+		return Block{Type: BlockParagraph, Text: strings.ReplaceAll(s.Text(), "\n", " ")}, true
+	case "h1":
+		return Block{Type: BlockHeading, Level: 1, Text: s.Text()}, true
+	case "h2":
+		return Block{Type: BlockHeading, Level: 2, Text: s.Text()}, true
+	case "h3":
+		return Block{Type: BlockHeading, Level: 3, Text: s.Text()}, true
+	case "h4":
+		return Block{Type: BlockHeading, Level: 4, Text: s.Text()}, true
+	case "h5":
+		return Block{Type: BlockHeading, Level: 5, Text: s.Text()}, true
+	case "h6":
+		return Block{Type: BlockHeading, Level: 6, Text: s.Text()}, true
+	case "blockquote":
+		return Block{Type: BlockBlockquote, Text: strings.TrimSpace(strings.ReplaceAll(s.Text(), "\n", " "))}, true
+	case "pre":
+		return Block{Type: BlockCode, Text: s.Text()}, true
+	case "ul", "ol":
+		return Block{Type: BlockList, Items: listItems(s), Ordered: s.Nodes[0].Data == "ol"}, true
+	}
+
+	return Block{}, false
+}
+
+// listItems collects the trimmed text of a list's direct <li> items.
+func listItems(s *goquery.Selection) []string {
+	var items []string
+
+	s.ChildrenFiltered("li").Each(func(i int, li *goquery.Selection) {
+		items = append(items, strings.TrimSpace(strings.ReplaceAll(li.Text(), "\n", " ")))
+	})
+
+	return items
+}
+
+// metadataFrom pulls page-level metadata out of the document: the title,
+// author, and canonical link from its <head> (the canonical URL falls back to
+// the document's own URL), and the language from the <html> lang attribute.
+func metadataFrom(doc *goquery.Document) Metadata {
+	meta := Metadata{
+		Title: strings.TrimSpace(doc.Find("title").First().Text()),
+	}
+
+	if doc.Url != nil {
+		meta.Canonical = doc.Url.String()
+	}
+
+	if href, ok := doc.Find(`link[rel="canonical"]`).First().Attr("href"); ok {
+		meta.Canonical = href
+	}
+
+	if author, ok := doc.Find(`meta[name="author"]`).First().Attr("content"); ok {
+		meta.Author = author
+	}
+
+	if lang, ok := doc.Find("html").First().Attr("lang"); ok {
+		meta.Language = lang
+	}
+
+	return meta
+}
@@ -0,0 +1,54 @@
+package plain
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDefaultExtractionCoversListsBlockquotesAndCode(t *testing.T) {
+	html := `<html><body>
+		<h1>Title</h1>
+		<p>A paragraph.</p>
+		<blockquote>A quoted thought.</blockquote>
+		<pre>fmt.Println("hi")</pre>
+		<ul><li>one</li><li>two</li></ul>
+		<ol><li>first</li><li>second</li></ol>
+	</body></html>`
+
+	doc, err := NewExtractor(Options{}).FromReader(strings.NewReader(html), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var gotList, gotOrderedList, gotQuote, gotCode bool
+	for _, b := range doc.Blocks {
+		switch b.Type {
+		case BlockList:
+			if b.Ordered {
+				gotOrderedList = true
+				if len(b.Items) != 2 || b.Items[0] != "first" {
+					t.Errorf("ordered list items = %v", b.Items)
+				}
+			} else {
+				gotList = true
+				if len(b.Items) != 2 || b.Items[0] != "one" {
+					t.Errorf("unordered list items = %v", b.Items)
+				}
+			}
+		case BlockBlockquote:
+			gotQuote = true
+			if b.Text != "A quoted thought." {
+				t.Errorf("blockquote text = %q", b.Text)
+			}
+		case BlockCode:
+			gotCode = true
+			if b.Text != `fmt.Println("hi")` {
+				t.Errorf("code text = %q", b.Text)
+			}
+		}
+	}
+
+	if !gotList || !gotOrderedList || !gotQuote || !gotCode {
+		t.Fatalf("expected list, ordered list, blockquote, and code blocks; got %+v", doc.Blocks)
+	}
+}
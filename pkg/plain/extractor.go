@@ -0,0 +1,97 @@
+package plain
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/url"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// Extractor pulls a structured Document out of an HTML page. The zero value
+// is a usable Extractor with ModeDefault and DefaultSelector.
+type Extractor struct {
+	Options Options
+}
+
+// NewExtractor returns an Extractor configured with the given Options.
+func NewExtractor(opts Options) *Extractor {
+	return &Extractor{Options: opts}
+}
+
+// FromURL retrieves the page at url and extracts a Document from it.
+func (e *Extractor) FromURL(ctx context.Context, rawURL string) (Document, error) {
+	response, err := loadPage(ctx, rawURL, e.Options.Client)
+	if err != nil {
+		return Document{}, err
+	}
+	defer response.Body.Close()
+
+	base, err := url.Parse(rawURL)
+	if err != nil {
+		return Document{}, err
+	}
+
+	return e.FromReader(response.Body, base)
+}
+
+// FromReader parses HTML read from r and extracts a Document from it. base is
+// used to resolve relative links and may be nil if that isn't needed.
+func (e *Extractor) FromReader(r io.Reader, base *url.URL) (Document, error) {
+	if r == nil {
+		return Document{}, errors.New("Nothing to see here!")
+	}
+
+	doc, err := goquery.NewDocumentFromReader(r)
+	if err != nil {
+		return Document{}, err
+	}
+
+	if base != nil {
+		doc.Url = base
+	}
+
+	return e.extractFromDocument(doc)
+}
+
+// extractFromDocument dispatches to the extraction strategy selected by
+// e.Options.Mode.
+func (e *Extractor) extractFromDocument(doc *goquery.Document) (Document, error) {
+	switch e.Options.Mode {
+	case ModeReadability:
+		return e.extractReadability(doc)
+	default:
+		return e.extractDefault(doc)
+	}
+}
+
+// extractDefault implements ModeDefault: every tag matched by the configured
+// selector, in document order, with no attempt to separate article content
+// from navigation, ads, or other boilerplate.
+func (e *Extractor) extractDefault(doc *goquery.Document) (Document, error) {
+	var blocks []Block
+
+	doc.Find(e.Options.selector()).Each(func(i int, s *goquery.Selection) {
+		if b, ok := blockFrom(s); ok {
+			blocks = append(blocks, b)
+		}
+	})
+
+	return Document{
+		Metadata: metadataFrom(doc),
+		Blocks:   blocks,
+	}, nil
+}
+
+// FromURL is a package-level convenience that retrieves the page at url and
+// extracts a Document from it using a one-off Extractor.
+func FromURL(ctx context.Context, url string, opts Options) (Document, error) {
+	return NewExtractor(opts).FromURL(ctx, url)
+}
+
+// FromReader is a package-level convenience that parses HTML read from r and
+// extracts a Document from it using a one-off Extractor.
+func FromReader(r io.Reader, base *url.URL, opts Options) (Document, error) {
+	return NewExtractor(opts).FromReader(r, base)
+}
@@ -0,0 +1,57 @@
+package plain
+
+import "fmt"
+
+// Mode selects the extraction strategy an Extractor uses to decide which
+// parts of a page belong in the resulting Document.
+type Mode int
+
+const (
+	// ModeDefault selects every tag matched by DefaultSelector (or
+	// Options.Selector, if set) -- paragraphs, headings, lists, blockquotes,
+	// and code blocks -- in document order. This is the original `plain`
+	// behavior, extended as new block types were added.
+	ModeDefault Mode = iota
+
+	// ModeReadability scores candidate elements the way Arc90's Readability
+	// algorithm does, picks the highest-scoring node as the article body, and
+	// discards navigation, sidebars, ads, and comments around it.
+	ModeReadability
+)
+
+// ParseMode parses the string form of a Mode, as accepted by the `-mode` CLI flag.
+func ParseMode(s string) (Mode, error) {
+	switch s {
+	case "", "default":
+		return ModeDefault, nil
+	case "readability":
+		return ModeReadability, nil
+	default:
+		return 0, fmt.Errorf("unknown mode %q", s)
+	}
+}
+
+// DefaultSelector matches the tags ModeDefault extracts blocks from:
+// paragraphs, headings, lists, blockquotes, and preformatted/code blocks.
+const DefaultSelector = "p, h1, h2, h3, h4, h5, h6, ul, ol, blockquote, pre"
+
+// Options configures an Extractor.
+type Options struct {
+	// Mode selects the extraction strategy. Defaults to ModeDefault.
+	Mode Mode
+
+	// Selector overrides the CSS selector ModeDefault uses to find
+	// text-bearing tags. Defaults to DefaultSelector.
+	Selector string
+
+	// Client configures the HTTP client used by FromURL and Crawl.
+	Client ClientOptions
+}
+
+func (o Options) selector() string {
+	if o.Selector == "" {
+		return DefaultSelector
+	}
+
+	return o.Selector
+}
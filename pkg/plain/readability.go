@@ -0,0 +1,159 @@
+package plain
+
+import (
+	"math"
+	"regexp"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"golang.org/x/net/html"
+)
+
+// candidateSelector matches the elements considered when scoring a page for
+// its main article body.
+const candidateSelector = "p, td, pre, article, section, div"
+
+// positiveClassWeight and negativeClassWeight match class/id names that raise
+// or lower a candidate's score, per the Arc90 Readability heuristic.
+var (
+	positiveClassWeight = regexp.MustCompile(`(?i)article|body|content|entry|main|post|text`)
+	negativeClassWeight = regexp.MustCompile(`(?i)comment|meta|footer|footnote|nav|sidebar|share|promo|ad-`)
+)
+
+// extractReadability implements the Arc90 Readability heuristic: candidate
+// elements are scored, the highest-scoring node is taken as the article root,
+// and low-value children (navigation, ads, comments, ...) are pruned from it
+// before the usual selector pulls out its blocks.
+func (e *Extractor) extractReadability(doc *goquery.Document) (Document, error) {
+	scores := scoreCandidates(doc)
+	if len(scores) == 0 {
+		return e.extractDefault(doc)
+	}
+
+	var topNode *html.Node
+	var topScore float64
+	for node, score := range scores {
+		if topNode == nil || score > topScore {
+			topNode, topScore = node, score
+		}
+	}
+
+	root := goquery.NewDocumentFromNode(topNode).Selection
+	pruneLowValueChildren(root)
+
+	var blocks []Block
+	root.Find(e.Options.selector()).Each(func(i int, s *goquery.Selection) {
+		if b, ok := blockFrom(s); ok {
+			blocks = append(blocks, b)
+		}
+	})
+
+	return Document{
+		Metadata: metadataFrom(doc),
+		Blocks:   blocks,
+	}, nil
+}
+
+// scoreCandidates scores every candidate element, propagates a fraction of
+// each score to its parent (full) and grandparent (half), and finally
+// discounts each total by the element's link density. The result maps each
+// scored node to its final score.
+func scoreCandidates(doc *goquery.Document) map[*html.Node]float64 {
+	base := map[*html.Node]float64{}
+
+	doc.Find(candidateSelector).Each(func(i int, s *goquery.Selection) {
+		base[s.Nodes[0]] = candidateScore(s)
+	})
+
+	scores := map[*html.Node]float64{}
+	for node, score := range base {
+		scores[node] += score
+	}
+
+	doc.Find(candidateSelector).Each(func(i int, s *goquery.Selection) {
+		score := base[s.Nodes[0]]
+
+		parent := s.Parent()
+		if parent.Length() == 0 {
+			return
+		}
+		scores[parent.Nodes[0]] += score
+
+		grandparent := parent.Parent()
+		if grandparent.Length() == 0 {
+			return
+		}
+		scores[grandparent.Nodes[0]] += score / 2
+	})
+
+	weighted := make(map[*html.Node]float64, len(scores))
+	for node, score := range scores {
+		sel := goquery.NewDocumentFromNode(node).Selection
+		weighted[node] = score * (1 - linkDensity(sel))
+	}
+
+	return weighted
+}
+
+// candidateScore computes a single element's base content score: one point,
+// plus one per comma, plus one per 100 characters of text (capped at three),
+// plus a class/id weight.
+func candidateScore(s *goquery.Selection) float64 {
+	text := s.Text()
+
+	score := 1.0
+	score += float64(strings.Count(text, ","))
+	score += math.Min(3, math.Floor(float64(len(text))/100))
+	score += classWeight(s)
+
+	return score
+}
+
+// classWeight adds 25 when a candidate's class or id looks like it names
+// article content, and subtracts 25 when it looks like boilerplate.
+func classWeight(s *goquery.Selection) float64 {
+	class, _ := s.Attr("class")
+	id, _ := s.Attr("id")
+	names := class + " " + id
+
+	var weight float64
+	if positiveClassWeight.MatchString(names) {
+		weight += 25
+	}
+	if negativeClassWeight.MatchString(names) {
+		weight -= 25
+	}
+
+	return weight
+}
+
+// linkDensity is the fraction of a selection's text that lives inside <a> tags.
+func linkDensity(s *goquery.Selection) float64 {
+	text := s.Text()
+	if len(text) == 0 {
+		return 0
+	}
+
+	var linkLen int
+	s.Find("a").Each(func(i int, a *goquery.Selection) {
+		linkLen += len(a.Text())
+	})
+
+	return float64(linkLen) / float64(len(text))
+}
+
+// pruneLowValueChildren drops direct children of the article root that are
+// mostly links, or that are short and contain no paragraph of their own —
+// the leftovers of navigation, share buttons, and similar boilerplate.
+func pruneLowValueChildren(root *goquery.Selection) {
+	root.Children().Each(func(i int, s *goquery.Selection) {
+		if linkDensity(s) > 0.5 {
+			s.Remove()
+			return
+		}
+
+		if len(strings.TrimSpace(s.Text())) < 25 && s.Find("p").Length() == 0 {
+			s.Remove()
+		}
+	})
+}
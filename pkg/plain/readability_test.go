@@ -0,0 +1,66 @@
+package plain
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func fromFixture(t *testing.T, name string) Document {
+	t.Helper()
+
+	f, err := os.Open("testdata/" + name)
+	if err != nil {
+		t.Fatalf("opening fixture: %v", err)
+	}
+	defer f.Close()
+
+	e := NewExtractor(Options{Mode: ModeReadability})
+	doc, err := e.FromReader(f, nil)
+	if err != nil {
+		t.Fatalf("extracting: %v", err)
+	}
+
+	return doc
+}
+
+func TestReadabilityDropsBoilerplate(t *testing.T) {
+	cases := []struct {
+		fixture  string
+		wantText string
+		wantNot  []string
+	}{
+		{
+			fixture:  "news-article.html",
+			wantText: "harbor reopened",
+			wantNot:  []string{"Subscribe for $1", "Privacy", "Great news, finally"},
+		},
+		{
+			fixture:  "blog-post.html",
+			wantText: "mechanical keyboard",
+			wantNot:  []string{"My Standing Desk Setup", "Which switches did you end up going with"},
+		},
+		{
+			fixture:  "wikipedia.html",
+			wantText: "plain text is a loose term",
+			wantNot:  []string{"Recent changes", "Disclaimers"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.fixture, func(t *testing.T) {
+			doc := fromFixture(t, c.fixture)
+			text := strings.ToLower(doc.Text())
+
+			if !strings.Contains(text, c.wantText) {
+				t.Errorf("expected extracted text to contain %q, got:\n%s", c.wantText, text)
+			}
+
+			for _, unwanted := range c.wantNot {
+				if strings.Contains(text, strings.ToLower(unwanted)) {
+					t.Errorf("expected extracted text to NOT contain %q, got:\n%s", unwanted, text)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package plain
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// Renderer writes a Document to w in some output format.
+type Renderer interface {
+	Render(doc Document, w io.Writer) error
+}
+
+// TextRenderer, MarkdownRenderer, JSONRenderer, and EPUBRenderer are the
+// built-in Renderers, selectable by name via RendererFor and the `-format`
+// CLI flag.
+var (
+	TextRenderer     Renderer = textRenderer{}
+	MarkdownRenderer Renderer = markdownRenderer{}
+	JSONRenderer     Renderer = jsonRenderer{}
+)
+
+// RendererFor returns the built-in Renderer named by format: "text", "md",
+// "json", or "epub". An empty format returns TextRenderer.
+func RendererFor(format string) (Renderer, error) {
+	switch format {
+	case "", "text":
+		return TextRenderer, nil
+	case "md":
+		return MarkdownRenderer, nil
+	case "json":
+		return JSONRenderer, nil
+	case "epub":
+		return EPUBRenderer, nil
+	default:
+		return nil, fmt.Errorf("unknown format %q", format)
+	}
+}
+
+type textRenderer struct{}
+
+func (textRenderer) Render(doc Document, w io.Writer) error {
+	_, err := io.WriteString(w, doc.Text())
+	return err
+}
+
+type markdownRenderer struct{}
+
+func (markdownRenderer) Render(doc Document, w io.Writer) error {
+	_, err := io.WriteString(w, doc.Markdown())
+	return err
+}
+
+type jsonRenderer struct{}
+
+func (jsonRenderer) Render(doc Document, w io.Writer) error {
+	return json.NewEncoder(w).Encode(doc)
+}
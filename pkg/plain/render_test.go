@@ -0,0 +1,115 @@
+package plain
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func testDocument() Document {
+	return Document{
+		Metadata: Metadata{Title: "Title"},
+		Blocks: []Block{
+			{Type: BlockHeading, Level: 1, Text: "Heading"},
+			{Type: BlockParagraph, Text: "A paragraph."},
+			{Type: BlockCode, Text: `fmt.Println("hi")`},
+			{Type: BlockList, Items: []string{"one", "two"}},
+			{Type: BlockList, Items: []string{"first", "second"}, Ordered: true},
+		},
+	}
+}
+
+func TestRendererForSelectsBuiltins(t *testing.T) {
+	cases := map[string]Renderer{
+		"":     TextRenderer,
+		"text": TextRenderer,
+		"md":   MarkdownRenderer,
+		"json": JSONRenderer,
+	}
+
+	for format, want := range cases {
+		got, err := RendererFor(format)
+		if err != nil {
+			t.Errorf("RendererFor(%q): %v", format, err)
+		}
+		if got != want {
+			t.Errorf("RendererFor(%q) = %v, want %v", format, got, want)
+		}
+	}
+
+	if _, err := RendererFor("bogus"); err == nil {
+		t.Error("expected an error for an unknown format")
+	}
+}
+
+func TestTextRendererWritesPlainText(t *testing.T) {
+	var buf bytes.Buffer
+	if err := TextRenderer.Render(testDocument(), &buf); err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(buf.String(), "HEADING") {
+		t.Errorf("expected upper-cased heading, got %q", buf.String())
+	}
+}
+
+func TestMarkdownRendererWritesCommonMark(t *testing.T) {
+	var buf bytes.Buffer
+	if err := MarkdownRenderer.Render(testDocument(), &buf); err != nil {
+		t.Fatal(err)
+	}
+
+	out := buf.String()
+	if !strings.Contains(out, "# Heading") {
+		t.Errorf("expected a level-1 heading, got %q", out)
+	}
+	if !strings.Contains(out, "- one") || !strings.Contains(out, "- two") {
+		t.Errorf("expected unordered list items as markdown bullets, got %q", out)
+	}
+	if !strings.Contains(out, "1. first") || !strings.Contains(out, "2. second") {
+		t.Errorf("expected an ordered list to render as a numbered list, got %q", out)
+	}
+	if !strings.Contains(out, "```\nfmt.Println(\"hi\")\n```") {
+		t.Errorf("expected a code block to be fenced, got %q", out)
+	}
+}
+
+func TestJSONRendererWritesValidJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := JSONRenderer.Render(testDocument(), &buf); err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(buf.String(), `"Type":"paragraph"`) {
+		t.Errorf("expected block types to encode as stable names, got %q", buf.String())
+	}
+
+	var decoded Document
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("output isn't valid JSON: %v", err)
+	}
+	if decoded.Metadata.Title != "Title" {
+		t.Errorf("decoded title = %q, want %q", decoded.Metadata.Title, "Title")
+	}
+	if len(decoded.Blocks) != len(testDocument().Blocks) {
+		t.Fatalf("decoded %d blocks, want %d", len(decoded.Blocks), len(testDocument().Blocks))
+	}
+	if decoded.Blocks[0].Type != BlockHeading {
+		t.Errorf("decoded block type = %v, want %v", decoded.Blocks[0].Type, BlockHeading)
+	}
+}
+
+func TestEPUBBodyRendersOrderedListsAndCode(t *testing.T) {
+	out := epubBody(testDocument())
+
+	if !strings.Contains(out, "<pre><code>fmt.Println(&#34;hi&#34;)</code></pre>") {
+		t.Errorf("expected a code block wrapped in <pre><code>, got %q", out)
+	}
+	if !strings.Contains(out, "<ul>\n<li>one</li>") {
+		t.Errorf("expected an unordered list as <ul>, got %q", out)
+	}
+	if !strings.Contains(out, "<ol>\n<li>first</li>") || !strings.Contains(out, "</ol>") {
+		t.Errorf("expected an ordered list as <ol>, got %q", out)
+	}
+}
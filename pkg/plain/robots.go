@@ -0,0 +1,80 @@
+package plain
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"strings"
+)
+
+// robotsRules is a minimal robots.txt ruleset: the Disallow prefixes that
+// apply to us, drawn from the "User-agent: *" group. Allow overrides and
+// other user-agent groups are not supported.
+type robotsRules struct {
+	disallow []string
+}
+
+// allows reports whether path may be fetched under these rules.
+func (rules *robotsRules) allows(path string) bool {
+	for _, prefix := range rules.disallow {
+		if strings.HasPrefix(path, prefix) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// fetchRobots retrieves and parses robots.txt for the given scheme and host,
+// through the same hardened client (timeout, retries, context cancellation)
+// used to fetch every other page. A missing or unreadable robots.txt is
+// treated as allowing everything.
+func fetchRobots(ctx context.Context, scheme, host string, opts ClientOptions) *robotsRules {
+	response, err := loadPage(ctx, scheme+"://"+host+"/robots.txt", opts)
+	if err != nil {
+		return &robotsRules{}
+	}
+	defer response.Body.Close()
+
+	return parseRobots(response.Body)
+}
+
+// parseRobots reads a robots.txt document and returns the Disallow rules
+// that apply to the "User-agent: *" group.
+func parseRobots(r io.Reader) *robotsRules {
+	rules := &robotsRules{}
+	appliesToUs := false
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		field, value, ok := splitRobotsLine(line)
+		if !ok {
+			continue
+		}
+
+		switch strings.ToLower(field) {
+		case "user-agent":
+			appliesToUs = value == "*"
+		case "disallow":
+			if appliesToUs && value != "" {
+				rules.disallow = append(rules.disallow, value)
+			}
+		}
+	}
+
+	return rules
+}
+
+func splitRobotsLine(line string) (field, value string, ok bool) {
+	parts := strings.SplitN(line, ":", 2)
+	if len(parts) != 2 {
+		return "", "", false
+	}
+
+	return strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1]), true
+}
@@ -0,0 +1,227 @@
+package plain
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// DefaultCacheSize is the number of extracted Documents a Server keeps cached
+// by default.
+const DefaultCacheSize = 128
+
+// DefaultCacheTTL is how long a cached Document stays fresh by default.
+const DefaultCacheTTL = 5 * time.Minute
+
+// ServerOptions configures a Server.
+type ServerOptions struct {
+	// Options are the extraction options applied to every request.
+	Options Options
+
+	// CacheSize is the maximum number of Documents to keep cached. Defaults
+	// to DefaultCacheSize.
+	CacheSize int
+
+	// CacheTTL is how long a cached Document stays fresh before a request
+	// re-extracts it. Defaults to DefaultCacheTTL.
+	CacheTTL time.Duration
+
+	// AllowPrivateHosts permits GET requests to fetch URLs that resolve to
+	// loopback, link-local, or other private address ranges. Unlike the CLI's
+	// -url flag, Server's ?url= parameter is reachable by anyone who can
+	// reach the listening address, so by default it's treated as untrusted
+	// input and blocked from reaching internal/metadata endpoints (SSRF).
+	// Only set this for deployments where the caller is already trusted.
+	AllowPrivateHosts bool
+}
+
+// Server exposes the extraction pipeline over HTTP, so `plain` can run as a
+// sidecar microservice for other tools.
+type Server struct {
+	extractor         *Extractor
+	cache             *cache
+	allowPrivateHosts bool
+}
+
+// NewServer returns a Server configured with the given ServerOptions.
+func NewServer(opts ServerOptions) *Server {
+	size := opts.CacheSize
+	if size == 0 {
+		size = DefaultCacheSize
+	}
+
+	ttl := opts.CacheTTL
+	if ttl == 0 {
+		ttl = DefaultCacheTTL
+	}
+
+	return &Server{
+		extractor:         NewExtractor(opts.Options),
+		cache:             newCache(size, ttl),
+		allowPrivateHosts: opts.AllowPrivateHosts,
+	}
+}
+
+// Handler returns an http.Handler exposing:
+//
+//	GET /plain?url=...&format=text|md|json|epub
+//	POST /plain?format=text|md|json|epub  (raw HTML in the request body)
+//
+// The GET path fetches whatever url a caller supplies, so unless
+// ServerOptions.AllowPrivateHosts is set, it refuses to fetch URLs that
+// resolve to loopback, link-local, or other private address ranges -- a
+// network-reachable `plain -serve` would otherwise act as an open proxy
+// onto internal services and cloud metadata endpoints.
+func (srv *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/plain", srv.handlePlain)
+	return mux
+}
+
+func (srv *Server) handlePlain(w http.ResponseWriter, r *http.Request) {
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = "text"
+	}
+
+	var (
+		doc Document
+		err error
+	)
+
+	switch r.Method {
+	case http.MethodGet:
+		url := r.URL.Query().Get("url")
+		if url == "" {
+			http.Error(w, "missing 'url' query parameter", http.StatusBadRequest)
+			return
+		}
+
+		if !srv.allowPrivateHosts {
+			if err := checkFetchAllowed(url); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+
+		key := url + "|" + format
+		if cached, ok := srv.cache.get(key); ok {
+			writeDocument(w, format, cached)
+			return
+		}
+
+		doc, err = srv.extractor.FromURL(r.Context(), url)
+		if err == nil {
+			srv.cache.set(key, doc)
+		}
+	case http.MethodPost:
+		defer r.Body.Close()
+		doc, err = srv.extractor.FromReader(r.Body, nil)
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+
+	writeDocument(w, format, doc)
+}
+
+func writeDocument(w http.ResponseWriter, format string, doc Document) {
+	renderer, err := RendererFor(format)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", contentTypeFor(format))
+	if err := renderer.Render(doc, w); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// checkFetchAllowed rejects rawURL if it isn't http(s), or if its host
+// resolves to a loopback, link-local, or other private address range.
+// Server's ?url= parameter is attacker-controlled input from the network, so
+// without this a `plain -serve` instance can be used as an open proxy to
+// reach internal services or cloud metadata endpoints (SSRF). This doesn't
+// defend against DNS rebinding between this check and the actual fetch;
+// deployments that need that guarantee should fetch through a proxy that
+// pins the resolved address.
+func checkFetchAllowed(rawURL string) error {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("invalid url: %w", err)
+	}
+
+	if parsed.Scheme != "http" && parsed.Scheme != "https" {
+		return fmt.Errorf("unsupported url scheme %q", parsed.Scheme)
+	}
+
+	host := parsed.Hostname()
+	if host == "" {
+		return fmt.Errorf("url has no host")
+	}
+
+	ips, err := net.LookupIP(host)
+	if err != nil {
+		return fmt.Errorf("could not resolve host %q: %w", host, err)
+	}
+
+	for _, ip := range ips {
+		if isPrivateAddr(ip) {
+			return fmt.Errorf("refusing to fetch %q: resolves to a private address", host)
+		}
+	}
+
+	return nil
+}
+
+// privateRanges are the address blocks reserved for private networks, loopback,
+// and link-local use, per RFC 1918, RFC 4193, and RFC 3927/4291.
+var privateRanges = mustParseCIDRs(
+	"127.0.0.0/8", "10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/16",
+	"169.254.0.0/16", "0.0.0.0/8",
+	"::1/128", "fc00::/7", "fe80::/10",
+)
+
+func mustParseCIDRs(cidrs ...string) []*net.IPNet {
+	nets := make([]*net.IPNet, len(cidrs))
+	for i, cidr := range cidrs {
+		_, n, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		nets[i] = n
+	}
+	return nets
+}
+
+// isPrivateAddr reports whether ip is loopback, link-local, or otherwise
+// reserved for private networks rather than the public internet.
+func isPrivateAddr(ip net.IP) bool {
+	for _, r := range privateRanges {
+		if r.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+func contentTypeFor(format string) string {
+	switch format {
+	case "json":
+		return "application/json"
+	case "md":
+		return "text/markdown"
+	case "epub":
+		return "application/epub+zip"
+	default:
+		return "text/plain"
+	}
+}
@@ -0,0 +1,54 @@
+package plain
+
+import (
+	"net"
+	"testing"
+)
+
+func TestCheckFetchAllowedRejectsPrivateAndNonHTTPTargets(t *testing.T) {
+	cases := map[string]bool{ // url -> want allowed
+		// Literal IPs so this test doesn't depend on real DNS resolution.
+		"http://93.184.216.34":   true,
+		"https://93.184.216.34":  true,
+		"http://127.0.0.1":       false,
+		"http://169.254.169.254": false,
+		"http://10.0.0.5":        false,
+		"file:///etc/passwd":     false,
+		"ftp://93.184.216.34":    false,
+	}
+
+	for url, wantAllowed := range cases {
+		err := checkFetchAllowed(url)
+		if gotAllowed := err == nil; gotAllowed != wantAllowed {
+			t.Errorf("checkFetchAllowed(%q) allowed = %v (err %v), want %v", url, gotAllowed, err, wantAllowed)
+		}
+	}
+}
+
+func TestIsPrivateAddr(t *testing.T) {
+	cases := map[string]bool{
+		"8.8.8.8":      false,
+		"127.0.0.1":    true,
+		"10.1.2.3":     true,
+		"172.16.0.1":   true,
+		"192.168.1.1":  true,
+		"169.254.1.1":  true,
+		"::1":          true,
+		"2001:4860::1": false,
+	}
+
+	for ip, want := range cases {
+		if got := isPrivateAddr(mustParseIP(t, ip)); got != want {
+			t.Errorf("isPrivateAddr(%q) = %v, want %v", ip, got, want)
+		}
+	}
+}
+
+func mustParseIP(t *testing.T, s string) net.IP {
+	t.Helper()
+	ip := net.ParseIP(s)
+	if ip == nil {
+		t.Fatalf("invalid test IP %q", s)
+	}
+	return ip
+}
@@ -1,21 +1,20 @@
 package main
 
 import (
-	"errors"
+	"bytes"
+	"context"
 	"flag"
 	"fmt"
 	"io/ioutil"
 	"net/http"
-	"strings"
+	"os"
 
-	"github.com/PuerkitoBio/goquery"
 	"github.com/prometheus/common/log"
+	"github.com/qsymmachus/plain/pkg/plain"
+	"golang.org/x/time/rate"
 )
 
-const (
-	DefaultURL   = "https://en.wikipedia.org/wiki/%22Hello,_World!%22_program"
-	TextSelector = "p, h1, h2, h3, h4, h5, h6"
-)
+const DefaultURL = "https://en.wikipedia.org/wiki/%22Hello,_World!%22_program"
 
 // Retrieves the document at the URL specified by the '-url' flag, and prints a
 // plaintext representation of its content to standard output. For example:
@@ -26,12 +25,46 @@ const (
 //
 //   plain -url http://example.com -file example-output.txt
 //
+// Use the '-format' flag to render as 'text' (default), 'md', 'json', or
+// 'epub' instead:
+//
+//   plain -url http://example.com -format epub -file example.epub
+//
+// Or start `plain` as an HTTP server with the '-serve' flag, which exposes the
+// extraction pipeline at GET /plain?url=...&format=text|md|json|epub instead
+// of running a single extraction:
+//
+//   plain -serve :8080
+//
+// Or crawl a whole site with the `crawl` subcommand, which follows same-host
+// links and writes one plaintext file per page:
+//
+//   plain crawl -url http://example.com -depth 2 -out ./example-archive
+//
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "crawl" {
+		runCrawl(os.Args[2:])
+		return
+	}
+
 	url := flag.String("url", DefaultURL, "URL of the page you'd like to read")
 	filepath := flag.String("file", "", "Optional filepath to output the page text")
+	mode := flag.String("mode", "default", "Extraction mode: 'default' or 'readability'")
+	format := flag.String("format", "text", "Output format: text, md, json, or epub")
+	addr := flag.String("serve", "", "Address to serve on (e.g. ':8080'); runs `plain` as an HTTP server instead of a one-off extraction")
+	timeout := flag.Duration("timeout", plain.DefaultTimeout, "Timeout for the HTTP request, including retries")
+	userAgent := flag.String("user-agent", plain.DefaultUserAgent, "User-Agent header to send")
+	retries := flag.Int("retries", plain.DefaultMaxRetries, "Number of retries on a 5xx response or network error (0 uses the default)")
 	flag.Parse()
 
-	text := makePlain(*url)
+	client := plain.ClientOptions{Timeout: *timeout, UserAgent: *userAgent, MaxRetries: *retries}
+
+	if *addr != "" {
+		serve(*addr, *mode, client)
+		return
+	}
+
+	text := makePlain(*url, *mode, *format, client)
 
 	if *filepath != "" {
 		if err := ioutil.WriteFile(*filepath, []byte(text), 0666); err != nil {
@@ -45,73 +78,83 @@ func main() {
 	}
 }
 
-// Given a URL, extracts the text we care about and returns it as a string ("make it plain!")
-func makePlain(url string) string {
-	response, err := loadPage(url)
+// Given a URL, extracts the content we care about and renders it in the given
+// format ("make it plain!").
+func makePlain(url string, mode string, format string, client plain.ClientOptions) string {
+	parsedMode, err := plain.ParseMode(mode)
 	if err != nil {
 		log.Error(err)
 	}
 
-	text, err := extractText(response)
+	doc, err := plain.FromURL(context.Background(), url, plain.Options{Mode: parsedMode, Client: client})
 	if err != nil {
 		log.Error(err)
 	}
 
-	return text
-}
-
-// Sends an HTTP request to the specified URL and returns the response.
-func loadPage(url string) (*http.Response, error) {
-	response, err := http.Get(url)
+	renderer, err := plain.RendererFor(format)
 	if err != nil {
-		return nil, err
+		log.Error(err)
+		renderer = plain.TextRenderer
 	}
 
-	if response.StatusCode != 200 {
-		return nil, fmt.Errorf("Unexpected status code: %s", response.Status)
+	var out bytes.Buffer
+	if err := renderer.Render(doc, &out); err != nil {
+		log.Error(err)
 	}
 
-	return response, nil
+	return out.String()
 }
 
-// Given an HTTP response, finds all HTML "text" tags and extracts their text content.
-// What we consider a "text tag" is defined in the `TextSelector` constant. Returns a
-// plaintext string of all the extracted text.
-func extractText(response *http.Response) (string, error) {
-	if response == nil {
-		return "", errors.New("Nothing to see here!")
-	}
-
-	defer response.Body.Close()
-	var textContents []string
-
-	doc, err := goquery.NewDocumentFromResponse(response)
+// Starts `plain` as an HTTP server listening on addr, serving extractions
+// made with the given mode.
+func serve(addr string, mode string, client plain.ClientOptions) {
+	parsedMode, err := plain.ParseMode(mode)
 	if err != nil {
-		return "", err
+		log.Error(err)
+		return
 	}
 
-	doc.Find(TextSelector).Each(func(i int, s *goquery.Selection) {
-		textContents = append(textContents, formatText(s))
-	})
+	server := plain.NewServer(plain.ServerOptions{Options: plain.Options{Mode: parsedMode, Client: client}})
 
-	return strings.Join(textContents, "\n\n"), nil
+	fmt.Printf("Serving plain text extraction on %s\n", addr)
+	if err := http.ListenAndServe(addr, server.Handler()); err != nil {
+		log.Error(err)
+	}
 }
 
-// Extracts and formats the text from a selected HTML tag. We capitalize headers, and
-// remove extra newlines that may be in paragraphs.
-func formatText(s *goquery.Selection) string {
-	if s == nil {
-		return ""
+// Runs the `crawl` subcommand: follows same-host links from '-url' up to
+// '-depth' hops, writing one plaintext file per page into '-out' along with a
+// manifest.json mapping URLs to files.
+func runCrawl(args []string) {
+	fs := flag.NewFlagSet("crawl", flag.ExitOnError)
+	url := fs.String("url", "", "URL to start crawling from")
+	depth := fs.Int("depth", 1, "How many hops from -url to follow same-host links")
+	out := fs.String("out", "./crawl", "Directory to write plaintext files and manifest.json to")
+	concurrency := fs.Int("concurrency", plain.DefaultCrawlConcurrency, "Number of pages to fetch at once")
+	requestsPerSecond := fs.Float64("rate", float64(plain.DefaultCrawlRateLimit), "Max requests per second to any single host")
+	timeout := fs.Duration("timeout", plain.DefaultTimeout, "Timeout for each HTTP request, including retries")
+	userAgent := fs.String("user-agent", plain.DefaultUserAgent, "User-Agent header to send")
+	retries := fs.Int("retries", plain.DefaultMaxRetries, "Number of retries on a 5xx response or network error (0 uses the default)")
+	fs.Parse(args)
+
+	if *url == "" {
+		fmt.Println("crawl: -url is required")
+		os.Exit(1)
 	}
 
-	var text string
-
-	switch s.Nodes[0].Data {
-	case "p":
-		text = strings.ReplaceAll(s.Text(), "\n", " ")
-	case "h1", "h2", "h3", "h4", "h5", "h6":
-		text = strings.ToUpper(s.Text())
+	manifest, err := plain.Crawl(context.Background(), *url, plain.CrawlOptions{
+		Depth:       *depth,
+		OutDir:      *out,
+		Concurrency: *concurrency,
+		RateLimit:   rate.Limit(*requestsPerSecond),
+		Options: plain.Options{
+			Client: plain.ClientOptions{Timeout: *timeout, UserAgent: *userAgent, MaxRetries: *retries},
+		},
+	})
+	if err != nil {
+		log.Error(err)
+		return
 	}
 
-	return text
+	fmt.Printf("Crawled %d pages into %s\n", len(manifest), *out)
 }